@@ -0,0 +1,30 @@
+package main
+
+import (
+	"time"
+)
+
+// readThrottle slows down a track read loop so that packets pile up in the
+// transport buffers, imitating a receiver that cannot keep up.
+type readThrottle struct {
+	config    BackpressureConfig
+	lastStall time.Time
+}
+
+func newReadThrottle(config BackpressureConfig) *readThrottle {
+	return &readThrottle{
+		config:    config,
+		lastStall: time.Now(),
+	}
+}
+
+func (t *readThrottle) Wait() {
+	if t.config.PacketDelay > 0 {
+		time.Sleep(t.config.PacketDelay)
+	}
+
+	if t.config.StallInterval > 0 && time.Since(t.lastStall) >= t.config.StallInterval {
+		time.Sleep(t.config.StallDuration)
+		t.lastStall = time.Now()
+	}
+}
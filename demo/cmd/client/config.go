@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bwe/demo/pkg/pionslog"
 	"flag"
 	"fmt"
 	"os"
@@ -10,11 +11,30 @@ import (
 )
 
 type Config struct {
-	Endpoint        string        `yaml:"endpoint"`
-	IceServer       string        `yaml:"ice_server"`
-	ReportFile      string        `yaml:"report_file"`
-	SessionDuration time.Duration `yaml:"session_duration"`
-	ReportInterval  time.Duration `yaml:"report_interval"`
+	Endpoint        string             `yaml:"endpoint"`
+	IceServer       string             `yaml:"ice_server"`
+	ReportFile      string             `yaml:"report_file"`
+	SessionDuration time.Duration      `yaml:"session_duration"`
+	ReportInterval  time.Duration      `yaml:"report_interval"` // how often stats are written to ReportFile as JSON
+	RecordDir       string             `yaml:"record_dir"`
+	RTCP            RTCPConfig         `yaml:"rtcp"`
+	Backpressure    BackpressureConfig `yaml:"backpressure"`
+	Log             pionslog.Config    `yaml:"log"`
+}
+
+type BackpressureConfig struct {
+	PacketDelay   time.Duration `yaml:"packet_delay"`
+	StallInterval time.Duration `yaml:"stall_interval"`
+	StallDuration time.Duration `yaml:"stall_duration"`
+}
+
+type RTCPConfig struct {
+	ReportInterval  time.Duration `yaml:"report_interval"` // how often RTCP receiver reports are sent, zero keeps pion's default
+	TWCCInterval    time.Duration `yaml:"twcc_interval"`
+	NACKInterval    time.Duration `yaml:"nack_interval"`
+	EarlyNACK       bool          `yaml:"early_nack"`
+	CompoundReports bool          `yaml:"compound_reports"`
+	LogFile         string        `yaml:"log_file"`
 }
 
 func LoadConfig() (Config, error) {
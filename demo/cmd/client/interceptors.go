@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bwe/demo/pkg/earlynack"
+	"bwe/demo/pkg/pioninterceptors"
+	"bwe/demo/pkg/rtcpcompound"
+	"bwe/demo/pkg/rtcplog"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/logging"
+	"github.com/pion/webrtc/v4"
+)
+
+func registerInterceptors(m *webrtc.MediaEngine, ir *interceptor.Registry, config RTCPConfig, rtcpLog io.Writer, loggerFactory logging.LoggerFactory) error {
+	if rtcpLog != nil {
+		ir.Add(rtcplog.NewInterceptor(rtcpLog))
+	}
+	if config.CompoundReports {
+		ir.Add(rtcpcompound.NewInterceptor(fmt.Sprintf("bwe-client-%08x", rand.Uint32())))
+	}
+
+	err := pioninterceptors.Register(m, ir, loggerFactory, pioninterceptors.Intervals{
+		Report: config.ReportInterval,
+		TWCC:   config.TWCCInterval,
+		NACK:   config.NACKInterval,
+	})
+	if err != nil {
+		return err
+	}
+
+	if config.EarlyNACK {
+		ir.Add(earlynack.NewInterceptor(loggerFactory.NewLogger("early_nack")))
+	}
+
+	return nil
+}
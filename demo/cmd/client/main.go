@@ -2,7 +2,10 @@ package main
 
 import (
 	"bwe/demo/pkg/attr"
+	"bwe/demo/pkg/pionslog"
 	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"sync"
@@ -10,7 +13,6 @@ import (
 
 	"github.com/pion/interceptor"
 	"github.com/pion/interceptor/pkg/stats"
-	"github.com/pion/logging"
 	"github.com/pion/webrtc/v4"
 	"golang.org/x/net/websocket"
 )
@@ -36,6 +38,13 @@ func main() {
 		return
 	}
 
+	logger, err := pionslog.NewLogger(config.Log)
+	if err != nil {
+		slog.Error("new logger", attr.Error(err))
+		return
+	}
+	slog.SetDefault(logger)
+
 	wsConfig, err := websocket.NewConfig(config.Endpoint, "http://localhost")
 	if err != nil {
 		slog.Error("new ws config", attr.Error(err))
@@ -55,10 +64,27 @@ func main() {
 		return
 	}
 
+	var rtcpLog io.Writer
+	if config.RTCP.LogFile != "" {
+		rtcpLogFile, err := os.Create(config.RTCP.LogFile)
+		if err != nil {
+			slog.Error("create rtcp log file", attr.Error(err))
+			return
+		}
+		defer rtcpLogFile.Close()
+		rtcpLog = rtcpLogFile
+	}
+
+	loggerFactory, err := pionslog.NewLoggerFactory(config.Log)
+	if err != nil {
+		slog.Error("new logger factory", attr.Error(err))
+		return
+	}
+
 	ir := &interceptor.Registry{}
-	err = webrtc.RegisterDefaultInterceptors(m, ir)
+	err = registerInterceptors(m, ir, config.RTCP, rtcpLog, loggerFactory)
 	if err != nil {
-		slog.Error("register default interceptors", attr.Error(err))
+		slog.Error("register interceptors", attr.Error(err))
 		return
 	}
 
@@ -75,7 +101,7 @@ func main() {
 	ir.Add(si)
 
 	se := webrtc.SettingEngine{}
-	se.LoggerFactory = logging.NewDefaultLoggerFactory()
+	se.LoggerFactory = loggerFactory
 
 	api := webrtc.NewAPI(webrtc.WithMediaEngine(m), webrtc.WithInterceptorRegistry(ir), webrtc.WithSettingEngine(se))
 	pc, err := api.NewPeerConnection(webrtc.Configuration{
@@ -106,10 +132,30 @@ func main() {
 		}
 	})
 
+	if config.RecordDir != "" {
+		err = os.MkdirAll(config.RecordDir, 0o755)
+		if err != nil {
+			slog.Error("create record dir", attr.Error(err))
+			return
+		}
+	}
+
+	var tracksMutex sync.Mutex
+	var tracks sync.WaitGroup
+	tracksClosed := false
 	var ssrcMutex sync.Mutex
 	ssrcMap := make(map[webrtc.SSRC]struct{}, 0)
 
 	pc.OnTrack(func(remote *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		tracksMutex.Lock()
+		if tracksClosed {
+			tracksMutex.Unlock()
+			return
+		}
+		tracks.Add(1)
+		tracksMutex.Unlock()
+		defer tracks.Done()
+
 		ssrc := remote.SSRC()
 		mid := receiver.RTPTransceiver().Mid()
 
@@ -127,13 +173,39 @@ func main() {
 			ssrcMutex.Unlock()
 		}()
 
-		buffer := make([]byte, 8000)
+		var recorder *trackRecorder
+		if config.RecordDir != "" {
+			var recorderErr error
+			recorder, recorderErr = newTrackRecorder(config.RecordDir, ssrc, remote.Codec().MimeType)
+			if recorderErr != nil {
+				slog.Error("new track recorder", attr.Error(recorderErr), attr.SSRC(ssrc))
+			} else {
+				defer func() {
+					if closeErr := recorder.Close(); closeErr != nil {
+						slog.Error("close track recorder", attr.Error(closeErr), attr.SSRC(ssrc))
+					}
+				}()
+			}
+		}
+
+		throttle := newReadThrottle(config.Backpressure)
 		for {
-			_, _, err = remote.Read(buffer)
-			if err != nil {
-				slog.Error("read remote track", attr.Error(err))
+			packet, _, readErr := remote.ReadRTP()
+			if errors.Is(readErr, io.EOF) {
+				return
+			}
+			if readErr != nil {
+				slog.Error("read remote track", attr.Error(readErr))
 				return
 			}
+
+			if recorder != nil {
+				if writeErr := recorder.WriteRTP(packet); writeErr != nil {
+					slog.Error("record packet", attr.Error(writeErr), attr.SSRC(ssrc))
+				}
+			}
+
+			throttle.Wait()
 		}
 	})
 
@@ -213,6 +285,16 @@ func main() {
 
 	time.Sleep(config.SessionDuration)
 
+	tracksMutex.Lock()
+	tracksClosed = true
+	tracksMutex.Unlock()
+
+	err = pc.Close()
+	if err != nil {
+		slog.Error("close peer connection", attr.Error(err))
+	}
+	tracks.Wait()
+
 	err = ws.Close()
 	if err != nil {
 		slog.Error("close ws", attr.Error(err))
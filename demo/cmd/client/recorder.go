@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bwe/demo/pkg/attr"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/pion/webrtc/v4/pkg/media/h264writer"
+	"github.com/pion/webrtc/v4/pkg/media/ivfwriter"
+)
+
+type FrameRecord struct {
+	Timestamp    int64  `json:"timestamp"`
+	RTPTimestamp uint32 `json:"rtp_timestamp"`
+	Size         int    `json:"size"`
+	Packets      int    `json:"packets"`
+}
+
+// trackRecorder writes a received track to a container file when the codec has one,
+// and always logs per-frame sizes and arrival times.
+type trackRecorder struct {
+	media      media.Writer
+	framesFile *os.File
+	frames     *json.Encoder
+	frame      FrameRecord
+	inFrame    bool
+}
+
+func newTrackRecorder(dir string, ssrc webrtc.SSRC, mimeType string) (*trackRecorder, error) {
+	base := filepath.Join(dir, fmt.Sprint(ssrc))
+
+	framesFile, err := os.Create(base + ".frames.log")
+	if err != nil {
+		return nil, fmt.Errorf("create frames file: %w", err)
+	}
+	r := &trackRecorder{
+		framesFile: framesFile,
+		frames:     json.NewEncoder(framesFile),
+	}
+
+	switch {
+	case strings.EqualFold(mimeType, webrtc.MimeTypeVP8), strings.EqualFold(mimeType, webrtc.MimeTypeAV1):
+		r.media, err = ivfwriter.New(base+".ivf", ivfwriter.WithCodec(mimeType))
+	case strings.EqualFold(mimeType, webrtc.MimeTypeH264):
+		r.media, err = h264writer.New(base + ".h264")
+	default:
+		slog.Warn("no container for codec, recording frames only", attr.SSRC(ssrc), slog.String("mime_type", mimeType))
+	}
+	if err != nil {
+		_ = framesFile.Close()
+		return nil, fmt.Errorf("new media writer: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *trackRecorder) WriteRTP(packet *rtp.Packet) error {
+	if r.inFrame && packet.Timestamp != r.frame.RTPTimestamp {
+		if err := r.flushFrame(); err != nil {
+			return err
+		}
+	}
+
+	r.inFrame = true
+	r.frame.Timestamp = time.Now().UnixNano()
+	r.frame.RTPTimestamp = packet.Timestamp
+	r.frame.Size += len(packet.Payload)
+	r.frame.Packets++
+
+	if r.media != nil {
+		if err := r.media.WriteRTP(packet); err != nil {
+			return fmt.Errorf("write media: %w", err)
+		}
+	}
+
+	if packet.Marker {
+		return r.flushFrame()
+	}
+	return nil
+}
+
+func (r *trackRecorder) flushFrame() error {
+	err := r.frames.Encode(r.frame)
+	r.frame = FrameRecord{}
+	r.inFrame = false
+	if err != nil {
+		return fmt.Errorf("encode frame: %w", err)
+	}
+	return nil
+}
+
+func (r *trackRecorder) Close() error {
+	var errs []error
+	if r.inFrame {
+		errs = append(errs, r.flushFrame())
+	}
+	if r.media != nil {
+		errs = append(errs, r.media.Close())
+	}
+	errs = append(errs, r.framesFile.Close())
+	return errors.Join(errs...)
+}
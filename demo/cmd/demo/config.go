@@ -1,17 +1,22 @@
 package main
 
 import (
+	"bwe/demo/pkg/pionslog"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Port       int      `yaml:"port"`
-	VideoPaths []string `yaml:"video_paths"`
-	IceServer  string   `yaml:"ice_server"`
+	Port              int             `yaml:"port"`
+	VideoPaths        []string        `yaml:"video_paths"`
+	Loop              bool            `yaml:"loop"`
+	H264FrameDuration time.Duration   `yaml:"h264_frame_duration"`
+	IceServer         string          `yaml:"ice_server"`
+	Log               pionslog.Config `yaml:"log"`
 }
 
 func LoadConfig() (Config, error) {
@@ -7,18 +7,18 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
 	"time"
 
 	"github.com/pion/webrtc/v4"
 	"github.com/pion/webrtc/v4/pkg/media"
-	"github.com/pion/webrtc/v4/pkg/media/ivfreader"
 	"golang.org/x/net/websocket"
 )
 
 type Handler struct {
 	PeerConnectionFactory PeerConnectionFactory
 	VideoPaths            []string
+	Loop                  bool
+	H264FrameDuration     time.Duration
 }
 
 func (h Handler) Watch(ws *websocket.Conn) {
@@ -35,10 +35,13 @@ func (h Handler) Watch(ws *websocket.Conn) {
 		}
 	}()
 
+	sessionCtx, sessionCtxCancel := context.WithCancel(context.Background())
+	defer sessionCtxCancel()
+
 	iceConnectedCtx, iceConnectedCtxCancel := context.WithCancel(context.Background())
 
 	for _, videoFileName := range h.VideoPaths {
-		err = startTrack(pc, videoFileName, iceConnectedCtx)
+		err = startTrack(sessionCtx, pc, videoFileName, h.Loop, h.H264FrameDuration, iceConnectedCtx)
 		if err != nil {
 			slog.Error("start track", attr.Error(err))
 		}
@@ -106,36 +109,21 @@ func (h Handler) Watch(ws *websocket.Conn) {
 	}
 }
 
-func startTrack(pc *webrtc.PeerConnection, videoPath string, iceConnectedCtx context.Context) error {
-	file, err := os.Open(videoPath)
+func startTrack(sessionCtx context.Context, pc *webrtc.PeerConnection, videoPath string, loop bool, h264FrameDuration time.Duration, iceConnectedCtx context.Context) error {
+	video, err := openVideoFile(videoPath, h264FrameDuration)
 	if err != nil {
-		return fmt.Errorf("open file: %w", err)
+		return fmt.Errorf("open video file: %w", err)
 	}
 
-	ivf, header, err := ivfreader.NewWith(file)
+	videoTrack, err := webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{MimeType: video.mimeType}, "video", "pion")
 	if err != nil {
-		return fmt.Errorf("new reader: %w", err)
-	}
-
-	var trackCodec string
-	switch header.FourCC {
-	case "AV01":
-		trackCodec = webrtc.MimeTypeAV1
-	case "VP90":
-		trackCodec = webrtc.MimeTypeVP9
-	case "VP80":
-		trackCodec = webrtc.MimeTypeVP8
-	default:
-		return fmt.Errorf("unable to handle FourCC %s", header.FourCC)
-	}
-
-	videoTrack, videoTrackErr := webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{MimeType: trackCodec}, "video", "pion")
-	if videoTrackErr != nil {
+		_ = video.Close()
 		return fmt.Errorf("new track local: %w", err)
 	}
 
-	rtpSender, videoTrackErr := pc.AddTrack(videoTrack)
-	if videoTrackErr != nil {
+	rtpSender, err := pc.AddTrack(videoTrack)
+	if err != nil {
+		_ = video.Close()
 		return fmt.Errorf("add track: %w", err)
 	}
 
@@ -149,23 +137,47 @@ func startTrack(pc *webrtc.PeerConnection, videoPath string, iceConnectedCtx con
 	}()
 
 	go func() {
-		<-iceConnectedCtx.Done()
-		ticker := time.NewTicker(time.Millisecond * time.Duration((float32(header.TimebaseNumerator)/float32(header.TimebaseDenominator))*1000))
-		for ; true; <-ticker.C {
-			frame, _, ivfErr := ivf.ParseNextFrame()
-			if errors.Is(ivfErr, io.EOF) {
+		defer func() {
+			if closeErr := video.Close(); closeErr != nil {
+				slog.Error("close video file", attr.Error(closeErr))
+			}
+		}()
+
+		select {
+		case <-sessionCtx.Done():
+			return
+		case <-iceConnectedCtx.Done():
+		}
+
+		ticker := time.NewTicker(video.frameDuration)
+		defer ticker.Stop()
+		for {
+			frame, readErr := video.NextFrame()
+			if errors.Is(readErr, io.EOF) && loop {
+				if readErr = video.Rewind(); readErr == nil {
+					frame, readErr = video.NextFrame()
+				}
+			}
+
+			if errors.Is(readErr, io.EOF) {
 				slog.Info(fmt.Sprintf("track %s over", videoPath))
 				return
 			}
 
-			if ivfErr != nil {
-				slog.Error("read ivf", attr.Error(err))
+			if readErr != nil {
+				slog.Error("read video file", attr.Error(readErr))
+				return
+			}
+
+			if writeErr := videoTrack.WriteSample(media.Sample{Data: frame, Duration: video.frameDuration}); writeErr != nil {
+				slog.Error("write sample", attr.Error(writeErr))
 				return
 			}
 
-			if ivfErr = videoTrack.WriteSample(media.Sample{Data: frame, Duration: time.Second}); ivfErr != nil {
-				slog.Error("write sample", attr.Error(err))
+			select {
+			case <-sessionCtx.Done():
 				return
+			case <-ticker.C:
 			}
 		}
 	}()
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bwe/demo/pkg/attr"
+	"bwe/demo/pkg/pionslog"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -16,6 +17,13 @@ func main() {
 		return
 	}
 
+	logger, err := pionslog.NewLogger(config.Log)
+	if err != nil {
+		slog.Error("new logger", attr.Error(err))
+		return
+	}
+	slog.SetDefault(logger)
+
 	pcFactory, err := newPeerConnectionFactory(config)
 	if err != nil {
 		slog.Error("new peer connection factory", attr.Error(err))
@@ -24,6 +32,8 @@ func main() {
 	handler := Handler{
 		PeerConnectionFactory: pcFactory,
 		VideoPaths:            config.VideoPaths,
+		Loop:                  config.Loop,
+		H264FrameDuration:     config.H264FrameDuration,
 	}
 
 	http.Handle("/watch", websocket.Handler(handler.Watch))
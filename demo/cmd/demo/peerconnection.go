@@ -1,10 +1,11 @@
 package main
 
 import (
+	"bwe/demo/pkg/pioninterceptors"
+	"bwe/demo/pkg/pionslog"
 	"fmt"
 
 	"github.com/pion/interceptor"
-	"github.com/pion/logging"
 	"github.com/pion/webrtc/v4"
 )
 
@@ -20,14 +21,19 @@ func newPeerConnectionFactory(config Config) (PeerConnectionFactory, error) {
 		return PeerConnectionFactory{}, fmt.Errorf("register default codecs: %w", err)
 	}
 
+	loggerFactory, err := pionslog.NewLoggerFactory(config.Log)
+	if err != nil {
+		return PeerConnectionFactory{}, fmt.Errorf("new logger factory: %w", err)
+	}
+
 	ir := &interceptor.Registry{}
-	err = webrtc.RegisterDefaultInterceptors(m, ir)
+	err = pioninterceptors.Register(m, ir, loggerFactory, pioninterceptors.Intervals{})
 	if err != nil {
-		return PeerConnectionFactory{}, fmt.Errorf("register default interceptors: %w", err)
+		return PeerConnectionFactory{}, fmt.Errorf("register interceptors: %w", err)
 	}
 
 	se := webrtc.SettingEngine{}
-	se.LoggerFactory = logging.NewDefaultLoggerFactory()
+	se.LoggerFactory = loggerFactory
 
 	return PeerConnectionFactory{
 		api:       webrtc.NewAPI(webrtc.WithMediaEngine(m), webrtc.WithInterceptorRegistry(ir), webrtc.WithSettingEngine(se)),
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media/h264reader"
+	"github.com/pion/webrtc/v4/pkg/media/ivfreader"
+)
+
+var annexBStartCode = []byte{0x00, 0x00, 0x00, 0x01}
+
+type videoFile struct {
+	file          *os.File
+	mimeType      string
+	frameDuration time.Duration
+	nextFrame     func() ([]byte, error)
+}
+
+func openVideoFile(path string, h264FrameDuration time.Duration) (*videoFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+
+	v := &videoFile{file: file}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".h264", ".264":
+		if h264FrameDuration <= 0 {
+			err = fmt.Errorf("h264 frame duration must be positive, got %s", h264FrameDuration)
+			break
+		}
+		v.mimeType = webrtc.MimeTypeH264
+		v.frameDuration = h264FrameDuration
+		err = v.initH264()
+	default:
+		err = v.initIVF()
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (v *videoFile) initIVF() error {
+	ivf, header, err := ivfreader.NewWith(v.file)
+	if err != nil {
+		return fmt.Errorf("new ivf reader: %w", err)
+	}
+
+	switch header.FourCC {
+	case "AV01":
+		v.mimeType = webrtc.MimeTypeAV1
+	case "VP90":
+		v.mimeType = webrtc.MimeTypeVP9
+	case "VP80":
+		v.mimeType = webrtc.MimeTypeVP8
+	default:
+		return fmt.Errorf("unable to handle FourCC %s", header.FourCC)
+	}
+
+	v.frameDuration = time.Duration(float64(time.Second) * float64(header.TimebaseNumerator) / float64(header.TimebaseDenominator))
+	v.nextFrame = func() ([]byte, error) {
+		frame, _, err := ivf.ParseNextFrame()
+		return frame, err
+	}
+	return nil
+}
+
+// initH264 groups NAL units into access units. An access unit ends before the next
+// AUD, SPS or PPS, or before a slice that starts a new picture (first_mb_in_slice == 0).
+func (v *videoFile) initH264() error {
+	h264, err := h264reader.NewReader(v.file)
+	if err != nil {
+		return fmt.Errorf("new h264 reader: %w", err)
+	}
+
+	var pending *h264reader.NAL
+	v.nextFrame = func() ([]byte, error) {
+		var frame []byte
+		hasSlice := false
+		for {
+			nal := pending
+			pending = nil
+			if nal == nil {
+				var nalErr error
+				nal, nalErr = h264.NextNAL()
+				if errors.Is(nalErr, io.EOF) && hasSlice {
+					return frame, nil
+				}
+				if nalErr != nil {
+					return nil, nalErr
+				}
+			}
+
+			if hasSlice && startsAccessUnit(nal) {
+				pending = nal
+				return frame, nil
+			}
+
+			frame = append(frame, annexBStartCode...)
+			frame = append(frame, nal.Data...)
+			if isSlice(nal) {
+				hasSlice = true
+			}
+		}
+	}
+	return nil
+}
+
+func isSlice(nal *h264reader.NAL) bool {
+	return nal.UnitType == h264reader.NalUnitTypeCodedSliceNonIdr || nal.UnitType == h264reader.NalUnitTypeCodedSliceIdr
+}
+
+func startsAccessUnit(nal *h264reader.NAL) bool {
+	switch nal.UnitType {
+	case h264reader.NalUnitTypeAUD, h264reader.NalUnitTypeSPS, h264reader.NalUnitTypePPS:
+		return true
+	case h264reader.NalUnitTypeCodedSliceNonIdr, h264reader.NalUnitTypeCodedSliceIdr:
+		// first_mb_in_slice is ue(v) coded, so a leading one bit means it is zero.
+		return len(nal.Data) > 1 && nal.Data[1]&0x80 != 0
+	default:
+		return false
+	}
+}
+
+func (v *videoFile) NextFrame() ([]byte, error) {
+	return v.nextFrame()
+}
+
+func (v *videoFile) Rewind() error {
+	_, err := v.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("seek: %w", err)
+	}
+
+	if v.mimeType == webrtc.MimeTypeH264 {
+		return v.initH264()
+	}
+	return v.initIVF()
+}
+
+func (v *videoFile) Close() error {
+	return v.file.Close()
+}
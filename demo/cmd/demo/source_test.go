@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func annexB(nals ...[]byte) []byte {
+	var b []byte
+	for _, nal := range nals {
+		b = append(b, annexBStartCode...)
+		b = append(b, nal...)
+	}
+	return b
+}
+
+func TestH264AccessUnits(t *testing.T) {
+	sps := []byte{0x67, 0x42, 0xc0, 0x1e}
+	pps := []byte{0x68, 0xce, 0x3c, 0x80}
+	idr := []byte{0x65, 0x88, 0x84}
+	// The second byte's top bit is set iff first_mb_in_slice is zero.
+	firstSlice := []byte{0x41, 0x9a, 0x01}
+	secondSlice := []byte{0x41, 0x40, 0x02}
+	aud := []byte{0x09, 0xf0}
+	audSlice := []byte{0x41, 0x9a, 0x03}
+
+	want := [][]byte{
+		annexB(sps, pps, idr),
+		annexB(firstSlice, secondSlice),
+		annexB(aud, audSlice),
+	}
+
+	path := filepath.Join(t.TempDir(), "video.h264")
+	if err := os.WriteFile(path, bytes.Join(want, nil), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	video, err := openVideoFile(path, 33*time.Millisecond)
+	if err != nil {
+		t.Fatalf("open video file: %v", err)
+	}
+	defer func() { _ = video.Close() }()
+
+	for i, wantFrame := range want {
+		frame, err := video.NextFrame()
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if !bytes.Equal(frame, wantFrame) {
+			t.Errorf("frame %d = %x, want %x", i, frame, wantFrame)
+		}
+	}
+	if _, err = video.NextFrame(); !errors.Is(err, io.EOF) {
+		t.Errorf("after last frame err = %v, want EOF", err)
+	}
+
+	if err = video.Rewind(); err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+	frame, err := video.NextFrame()
+	if err != nil {
+		t.Fatalf("frame after rewind: %v", err)
+	}
+	if !bytes.Equal(frame, want[0]) {
+		t.Errorf("frame after rewind = %x, want %x", frame, want[0])
+	}
+}
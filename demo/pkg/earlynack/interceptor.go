@@ -0,0 +1,118 @@
+package earlynack
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/logging"
+	"github.com/pion/rtcp"
+)
+
+// maxGap bounds the number of sequence numbers requested at once; larger jumps are treated as a stream restart.
+const maxGap = 256
+
+type InterceptorFactory struct {
+	log logging.LeveledLogger
+}
+
+func NewInterceptor(log logging.LeveledLogger) *InterceptorFactory {
+	return &InterceptorFactory{log: log}
+}
+
+func (f *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+	return &Interceptor{
+		senderSSRC: rand.Uint32(),
+		log:        f.log,
+	}, nil
+}
+
+// Interceptor sends a NACK (RFC 4585 early feedback) as soon as a sequence number gap is seen,
+// instead of waiting for the next tick of the periodic NACK generator.
+type Interceptor struct {
+	interceptor.NoOp
+	senderSSRC uint32
+	log        logging.LeveledLogger
+
+	mu     sync.Mutex
+	writer interceptor.RTCPWriter
+}
+
+func (i *Interceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.writer = writer
+	return writer
+}
+
+func (i *Interceptor) BindRemoteStream(info *interceptor.StreamInfo, reader interceptor.RTPReader) interceptor.RTPReader {
+	if !supportsNack(info) {
+		return reader
+	}
+
+	var (
+		started bool
+		lastSeq uint16
+	)
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, a, err := reader.Read(b, a)
+		if err != nil {
+			return n, a, err
+		}
+
+		if a == nil {
+			a = make(interceptor.Attributes)
+		}
+		header, err := a.GetRTPHeader(b[:n])
+		if err != nil {
+			return 0, nil, err
+		}
+
+		seq := header.SequenceNumber
+		gap := seq - lastSeq
+		if !started {
+			started = true
+			lastSeq = seq
+		} else if gap != 0 && gap < 0x8000 {
+			lastSeq = seq
+			if gap > 1 && gap <= maxGap {
+				i.sendNack(info.SSRC, seq-gap+1, seq)
+			}
+		}
+
+		return n, a, nil
+	})
+}
+
+func (i *Interceptor) sendNack(mediaSSRC uint32, from, to uint16) {
+	missing := make([]uint16, 0, to-from)
+	for seq := from; seq != to; seq++ {
+		missing = append(missing, seq)
+	}
+
+	i.mu.Lock()
+	writer := i.writer
+	i.mu.Unlock()
+	if writer == nil {
+		return
+	}
+
+	_, err := writer.Write([]rtcp.Packet{&rtcp.TransportLayerNack{
+		SenderSSRC: i.senderSSRC,
+		MediaSSRC:  mediaSSRC,
+		Nacks:      rtcp.NackPairsFromSequenceNumbers(missing),
+	}}, interceptor.Attributes{})
+	if err != nil {
+		i.log.Errorf("write early nack: %v", err)
+	}
+}
+
+func supportsNack(info *interceptor.StreamInfo) bool {
+	for _, fb := range info.RTCPFeedback {
+		if fb.Type == "nack" && fb.Parameter == "" {
+			return true
+		}
+	}
+	return false
+}
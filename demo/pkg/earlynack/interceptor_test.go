@@ -0,0 +1,106 @@
+package earlynack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/logging"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+func TestBindRemoteStream(t *testing.T) {
+	tests := []struct {
+		name string
+		seqs []uint16
+		want [][]rtcp.NackPair
+	}{
+		{
+			name: "first packet",
+			seqs: []uint16{100},
+		},
+		{
+			name: "gap of one",
+			seqs: []uint16{100, 101, 102},
+		},
+		{
+			name: "single loss",
+			seqs: []uint16{100, 102},
+			want: [][]rtcp.NackPair{{{PacketID: 101}}},
+		},
+		{
+			name: "burst loss",
+			seqs: []uint16{100, 104, 105},
+			want: [][]rtcp.NackPair{{{PacketID: 101, LostPackets: 0b11}}},
+		},
+		{
+			name: "gap above max gap",
+			seqs: []uint16{100, 100 + maxGap + 1, 100 + maxGap + 3},
+			want: [][]rtcp.NackPair{{{PacketID: 100 + maxGap + 2}}},
+		},
+		{
+			name: "reordered packet",
+			seqs: []uint16{100, 103, 101, 102, 104},
+			want: [][]rtcp.NackPair{{{PacketID: 101, LostPackets: 0b1}}},
+		},
+		{
+			name: "wraparound without loss",
+			seqs: []uint16{65534, 65535, 0, 1},
+		},
+		{
+			name: "wraparound with loss",
+			seqs: []uint16{65534, 1},
+			want: [][]rtcp.NackPair{{{PacketID: 65535, LostPackets: 0b1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Interceptor{
+				senderSSRC: 1,
+				log:        logging.NewDefaultLoggerFactory().NewLogger("early_nack"),
+			}
+
+			var got [][]rtcp.NackPair
+			i.BindRTCPWriter(interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, _ interceptor.Attributes) (int, error) {
+				for _, pkt := range pkts {
+					nack, ok := pkt.(*rtcp.TransportLayerNack)
+					if !ok {
+						t.Fatalf("unexpected packet %T", pkt)
+					}
+					if nack.SenderSSRC != 1 || nack.MediaSSRC != 2 {
+						t.Errorf("nack ssrcs = %d/%d, want 1/2", nack.SenderSSRC, nack.MediaSSRC)
+					}
+					got = append(got, nack.Nacks)
+				}
+				return 0, nil
+			}))
+
+			var next []byte
+			reader := i.BindRemoteStream(&interceptor.StreamInfo{
+				SSRC:         2,
+				RTCPFeedback: []interceptor.RTCPFeedback{{Type: "nack"}},
+			}, interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+				return copy(b, next), a, nil
+			}))
+
+			buf := make([]byte, 1500)
+			for _, seq := range tt.seqs {
+				packet := &rtp.Packet{Header: rtp.Header{Version: 2, SequenceNumber: seq, SSRC: 2}}
+				var err error
+				next, err = packet.Marshal()
+				if err != nil {
+					t.Fatalf("marshal: %v", err)
+				}
+				if _, _, err = reader.Read(buf, nil); err != nil {
+					t.Fatalf("read: %v", err)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nacks = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
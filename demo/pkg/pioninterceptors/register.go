@@ -0,0 +1,82 @@
+package pioninterceptors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/pkg/nack"
+	"github.com/pion/interceptor/pkg/report"
+	"github.com/pion/interceptor/pkg/twcc"
+	"github.com/pion/logging"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v4"
+)
+
+// Intervals overrides the feedback timing of the registered interceptors; zero keeps pion's default.
+type Intervals struct {
+	Report time.Duration
+	TWCC   time.Duration
+	NACK   time.Duration
+}
+
+// Register mirrors webrtc.RegisterDefaultInterceptors, but logs through loggerFactory
+// and applies the given intervals.
+func Register(m *webrtc.MediaEngine, ir *interceptor.Registry, loggerFactory logging.LoggerFactory, intervals Intervals) error {
+	generatorOpts := []nack.GeneratorOption{nack.GeneratorLog(loggerFactory.NewLogger("nack_generator"))}
+	if intervals.NACK != 0 {
+		generatorOpts = append(generatorOpts, nack.GeneratorInterval(intervals.NACK))
+	}
+	generator, err := nack.NewGeneratorInterceptor(generatorOpts...)
+	if err != nil {
+		return fmt.Errorf("new nack generator: %w", err)
+	}
+	responder, err := nack.NewResponderInterceptor(nack.ResponderLog(loggerFactory.NewLogger("nack_responder")))
+	if err != nil {
+		return fmt.Errorf("new nack responder: %w", err)
+	}
+	m.RegisterFeedback(webrtc.RTCPFeedback{Type: "nack"}, webrtc.RTPCodecTypeVideo)
+	m.RegisterFeedback(webrtc.RTCPFeedback{Type: "nack", Parameter: "pli"}, webrtc.RTPCodecTypeVideo)
+	ir.Add(responder)
+	ir.Add(generator)
+
+	receiverOpts := []report.ReceiverOption{report.ReceiverLog(loggerFactory.NewLogger("receiver_interceptor"))}
+	if intervals.Report != 0 {
+		receiverOpts = append(receiverOpts, report.ReceiverInterval(intervals.Report))
+	}
+	receiver, err := report.NewReceiverInterceptor(receiverOpts...)
+	if err != nil {
+		return fmt.Errorf("new receiver report interceptor: %w", err)
+	}
+	sender, err := report.NewSenderInterceptor(report.SenderLog(loggerFactory.NewLogger("sender_interceptor")))
+	if err != nil {
+		return fmt.Errorf("new sender report interceptor: %w", err)
+	}
+	ir.Add(receiver)
+	ir.Add(sender)
+
+	err = webrtc.ConfigureSimulcastExtensionHeaders(m)
+	if err != nil {
+		return fmt.Errorf("configure simulcast extension headers: %w", err)
+	}
+
+	// The twcc sender has no log option, so it keeps pion's default logger.
+	var twccOpts []twcc.Option
+	if intervals.TWCC != 0 {
+		twccOpts = append(twccOpts, twcc.SendInterval(intervals.TWCC))
+	}
+	twccSender, err := twcc.NewSenderInterceptor(twccOpts...)
+	if err != nil {
+		return fmt.Errorf("new twcc sender: %w", err)
+	}
+	for _, codecType := range []webrtc.RTPCodecType{webrtc.RTPCodecTypeVideo, webrtc.RTPCodecTypeAudio} {
+		m.RegisterFeedback(webrtc.RTCPFeedback{Type: webrtc.TypeRTCPFBTransportCC}, codecType)
+		err = m.RegisterHeaderExtension(webrtc.RTPHeaderExtensionCapability{URI: sdp.TransportCCURI}, codecType)
+		if err != nil {
+			return fmt.Errorf("register transport cc extension: %w", err)
+		}
+	}
+	ir.Add(twccSender)
+
+	return nil
+}
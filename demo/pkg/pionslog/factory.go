@@ -0,0 +1,154 @@
+package pionslog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/pion/logging"
+)
+
+const LevelTrace = slog.LevelDebug - 4
+
+type Config struct {
+	Format    string            `yaml:"format"`
+	Level     string            `yaml:"level"`
+	PionLevel string            `yaml:"pion_level"`
+	Scopes    map[string]string `yaml:"scopes"`
+}
+
+// NewLogger returns the application logger described by config.
+func NewLogger(config Config) (*slog.Logger, error) {
+	level, err := parseLevel(config.Level, slog.LevelInfo)
+	if err != nil {
+		return nil, fmt.Errorf("parse level: %w", err)
+	}
+
+	handler, err := newHandler(config.Format, level, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(handler), nil
+}
+
+// LoggerFactory implements logging.LoggerFactory on top of slog.
+// Each pion scope gets its own level, falling back to PionLevel, which defaults to error like pion's own factory.
+type LoggerFactory struct {
+	handler      slog.Handler
+	defaultLevel slog.Level
+	scopeLevels  map[string]slog.Level
+}
+
+func NewLoggerFactory(config Config) (*LoggerFactory, error) {
+	defaultLevel, err := parseLevel(config.PionLevel, slog.LevelError)
+	if err != nil {
+		return nil, fmt.Errorf("parse pion level: %w", err)
+	}
+
+	scopeLevels := make(map[string]slog.Level, len(config.Scopes))
+	for scope, levelName := range config.Scopes {
+		level, err := parseLevel(levelName, defaultLevel)
+		if err != nil {
+			return nil, fmt.Errorf("parse level of scope %s: %w", scope, err)
+		}
+		scopeLevels[scope] = level
+	}
+
+	handler, err := newHandler(config.Format, LevelTrace, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoggerFactory{
+		handler:      handler,
+		defaultLevel: defaultLevel,
+		scopeLevels:  scopeLevels,
+	}, nil
+}
+
+func (f *LoggerFactory) NewLogger(scope string) logging.LeveledLogger {
+	level, ok := f.scopeLevels[scope]
+	if !ok {
+		level = f.defaultLevel
+	}
+
+	return &Logger{
+		logger: slog.New(f.handler).With(slog.String("scope", scope)),
+		level:  level,
+	}
+}
+
+type Logger struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func (l *Logger) log(level slog.Level, msg string) {
+	if level < l.level {
+		return
+	}
+	l.logger.Log(context.Background(), level, strings.TrimSuffix(msg, "\n"))
+}
+
+func (l *Logger) logf(level slog.Level, format string, args ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.logger.Log(context.Background(), level, strings.TrimSuffix(fmt.Sprintf(format, args...), "\n"))
+}
+
+func (l *Logger) Trace(msg string) { l.log(LevelTrace, msg) }
+
+func (l *Logger) Tracef(format string, args ...interface{}) { l.logf(LevelTrace, format, args...) }
+
+func (l *Logger) Debug(msg string) { l.log(slog.LevelDebug, msg) }
+
+func (l *Logger) Debugf(format string, args ...interface{}) { l.logf(slog.LevelDebug, format, args...) }
+
+func (l *Logger) Info(msg string) { l.log(slog.LevelInfo, msg) }
+
+func (l *Logger) Infof(format string, args ...interface{}) { l.logf(slog.LevelInfo, format, args...) }
+
+func (l *Logger) Warn(msg string) { l.log(slog.LevelWarn, msg) }
+
+func (l *Logger) Warnf(format string, args ...interface{}) { l.logf(slog.LevelWarn, format, args...) }
+
+func (l *Logger) Error(msg string) { l.log(slog.LevelError, msg) }
+
+func (l *Logger) Errorf(format string, args ...interface{}) { l.logf(slog.LevelError, format, args...) }
+
+func newHandler(format string, level slog.Leveler, w io.Writer) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && a.Value.Any() == LevelTrace {
+				return slog.String(slog.LevelKey, "TRACE")
+			}
+			return a
+		},
+	}
+	switch format {
+	case "", "text":
+		return slog.NewTextHandler(w, opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+func parseLevel(name string, fallback slog.Level) (slog.Level, error) {
+	switch {
+	case name == "":
+		return fallback, nil
+	case strings.EqualFold(name, "trace"):
+		return LevelTrace, nil
+	}
+
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
@@ -0,0 +1,79 @@
+package rtcpcompound
+
+import (
+	"math/rand"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+)
+
+type InterceptorFactory struct {
+	cname string
+}
+
+func NewInterceptor(cname string) *InterceptorFactory {
+	return &InterceptorFactory{
+		cname: cname,
+	}
+}
+
+func (f *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+	return &Interceptor{
+		cname: f.cname,
+		ssrc:  rand.Uint32(),
+	}, nil
+}
+
+// Interceptor turns every outgoing RTCP batch into an RFC 3550 compound packet:
+// the batch's reports moved to the front (an empty receiver report if it has none), an SDES CNAME, then the rest.
+// Without it pion sends reduced-size RTCP (RFC 5506).
+type Interceptor struct {
+	interceptor.NoOp
+	cname string
+	ssrc  uint32
+}
+
+func (i *Interceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	return interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, attributes interceptor.Attributes) (int, error) {
+		if len(pkts) == 0 {
+			return writer.Write(pkts, attributes)
+		}
+
+		for _, pkt := range pkts {
+			if _, ok := pkt.(*rtcp.SourceDescription); ok {
+				return writer.Write(pkts, attributes)
+			}
+		}
+
+		var reports []rtcp.Packet
+		var ssrc uint32
+		rest := make([]rtcp.Packet, 0, len(pkts))
+		for _, pkt := range pkts {
+			switch pkt := pkt.(type) {
+			case *rtcp.ReceiverReport:
+				if len(reports) == 0 {
+					ssrc = pkt.SSRC
+				}
+				reports = append(reports, pkt)
+			case *rtcp.SenderReport:
+				if len(reports) == 0 {
+					ssrc = pkt.SSRC
+				}
+				reports = append(reports, pkt)
+			default:
+				rest = append(rest, pkt)
+			}
+		}
+		if len(reports) == 0 {
+			ssrc = i.ssrc
+			reports = append(reports, &rtcp.ReceiverReport{SSRC: ssrc})
+		}
+
+		compound := make([]rtcp.Packet, 0, len(reports)+len(rest)+1)
+		compound = append(compound, reports...)
+		compound = append(compound, rtcp.NewCNAMESourceDescription(ssrc, i.cname))
+		compound = append(compound, rest...)
+
+		return writer.Write(compound, attributes)
+	})
+}
@@ -0,0 +1,73 @@
+package rtcpcompound
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+)
+
+func TestBindRTCPWriter(t *testing.T) {
+	rr := &rtcp.ReceiverReport{SSRC: 1}
+	sr := &rtcp.SenderReport{SSRC: 2}
+	sdes := rtcp.NewCNAMESourceDescription(1, "other")
+	twcc := &rtcp.TransportLayerCC{SenderSSRC: 1, MediaSSRC: 3}
+	pli := &rtcp.PictureLossIndication{SenderSSRC: 1, MediaSSRC: 3}
+
+	tests := []struct {
+		name string
+		pkts []rtcp.Packet
+		want []rtcp.Packet
+	}{
+		{
+			name: "empty batch",
+			pkts: []rtcp.Packet{},
+			want: []rtcp.Packet{},
+		},
+		{
+			name: "sdes already present",
+			pkts: []rtcp.Packet{twcc, sdes},
+			want: []rtcp.Packet{twcc, sdes},
+		},
+		{
+			name: "receiver report only",
+			pkts: []rtcp.Packet{rr},
+			want: []rtcp.Packet{rr, rtcp.NewCNAMESourceDescription(1, "test")},
+		},
+		{
+			name: "report after feedback",
+			pkts: []rtcp.Packet{twcc, rr},
+			want: []rtcp.Packet{rr, rtcp.NewCNAMESourceDescription(1, "test"), twcc},
+		},
+		{
+			name: "all reports precede sdes",
+			pkts: []rtcp.Packet{twcc, rr, pli, sr},
+			want: []rtcp.Packet{rr, sr, rtcp.NewCNAMESourceDescription(1, "test"), twcc, pli},
+		},
+		{
+			name: "feedback only",
+			pkts: []rtcp.Packet{twcc},
+			want: []rtcp.Packet{&rtcp.ReceiverReport{SSRC: 9}, rtcp.NewCNAMESourceDescription(9, "test"), twcc},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &Interceptor{cname: "test", ssrc: 9}
+
+			var got []rtcp.Packet
+			writer := i.BindRTCPWriter(interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, _ interceptor.Attributes) (int, error) {
+				got = pkts
+				return 0, nil
+			}))
+			if _, err := writer.Write(tt.pkts, interceptor.Attributes{}); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("written packets =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package rtcplog
+
+import (
+	"log/slog"
+
+	"github.com/pion/rtcp"
+)
+
+type TransportFeedbackPacket struct {
+	MediaSSRC      uint32 `json:"media_ssrc"`
+	FeedbackCount  uint8  `json:"feedback_count"`
+	SequenceNumber uint16 `json:"sequence_number"`
+	Received       bool   `json:"received"`
+	// Delta and ArrivalTime are in microseconds, ArrivalTime on the feedback's reference clock.
+	Delta       int64 `json:"delta"`
+	ArrivalTime int64 `json:"arrival_time"`
+	// MissingDelta marks a packet reported as received whose receive delta is absent from the feedback.
+	MissingDelta bool `json:"missing_delta,omitempty"`
+}
+
+type CCFeedbackPacket struct {
+	MediaSSRC      uint32 `json:"media_ssrc"`
+	SequenceNumber uint16 `json:"sequence_number"`
+	Received       bool   `json:"received"`
+	ECN            uint8  `json:"ecn"`
+	// ArrivalTimeOffset is in 1/1024 s before the report timestamp.
+	ArrivalTimeOffset uint16 `json:"arrival_time_offset"`
+	ReportTimestamp   uint32 `json:"report_timestamp"`
+}
+
+func decodeTransportFeedback(cc *rtcp.TransportLayerCC) []TransportFeedbackPacket {
+	symbols := make([]uint16, 0, cc.PacketStatusCount)
+	for _, chunk := range cc.PacketChunks {
+		switch chunk := chunk.(type) {
+		case *rtcp.RunLengthChunk:
+			for i := uint16(0); i < chunk.RunLength; i++ {
+				symbols = append(symbols, chunk.PacketStatusSymbol)
+			}
+		case *rtcp.StatusVectorChunk:
+			symbols = append(symbols, chunk.SymbolList...)
+		}
+	}
+	if len(symbols) > int(cc.PacketStatusCount) {
+		symbols = symbols[:cc.PacketStatusCount]
+	}
+
+	packets := make([]TransportFeedbackPacket, 0, len(symbols))
+	arrivalTime := int64(cc.ReferenceTime) * 64000
+	deltaIndex := 0
+	missingDeltas := 0
+	for i, symbol := range symbols {
+		packet := TransportFeedbackPacket{
+			MediaSSRC:      cc.MediaSSRC,
+			FeedbackCount:  cc.FbPktCount,
+			SequenceNumber: cc.BaseSequenceNumber + uint16(i),
+		}
+		switch symbol {
+		case rtcp.TypeTCCPacketReceivedSmallDelta, rtcp.TypeTCCPacketReceivedLargeDelta:
+			packet.Received = true
+			if deltaIndex >= len(cc.RecvDeltas) {
+				packet.MissingDelta = true
+				missingDeltas++
+				break
+			}
+			packet.Delta = cc.RecvDeltas[deltaIndex].Delta
+			arrivalTime += packet.Delta
+			packet.ArrivalTime = arrivalTime
+			deltaIndex++
+		case rtcp.TypeTCCPacketReceivedWithoutDelta:
+			packet.Received = true
+		}
+		packets = append(packets, packet)
+	}
+
+	if missingDeltas > 0 {
+		slog.Warn("transport feedback has fewer receive deltas than received packets",
+			slog.Uint64("media_ssrc", uint64(cc.MediaSSRC)), slog.Int("missing", missingDeltas))
+	}
+
+	return packets
+}
+
+func decodeCCFeedback(report *rtcp.CCFeedbackReport) []CCFeedbackPacket {
+	var packets []CCFeedbackPacket
+	for _, block := range report.ReportBlocks {
+		for i, metric := range block.MetricBlocks {
+			packets = append(packets, CCFeedbackPacket{
+				MediaSSRC:         block.MediaSSRC,
+				SequenceNumber:    block.BeginSequence + uint16(i),
+				Received:          metric.Received,
+				ECN:               uint8(metric.ECN),
+				ArrivalTimeOffset: metric.ArrivalTimeOffset,
+				ReportTimestamp:   report.ReportTimestamp,
+			})
+		}
+	}
+
+	return packets
+}
@@ -0,0 +1,156 @@
+package rtcplog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pion/rtcp"
+)
+
+func TestDecodeTransportFeedback(t *testing.T) {
+	tests := []struct {
+		name      string
+		cc        *rtcp.TransportLayerCC
+		roundTrip bool
+		want      []TransportFeedbackPacket
+	}{
+		{
+			name: "run length chunk",
+			cc: &rtcp.TransportLayerCC{
+				MediaSSRC:          1,
+				BaseSequenceNumber: 10,
+				PacketStatusCount:  3,
+				ReferenceTime:      2,
+				FbPktCount:         5,
+				PacketChunks: []rtcp.PacketStatusChunk{
+					&rtcp.RunLengthChunk{PacketStatusSymbol: rtcp.TypeTCCPacketReceivedSmallDelta, RunLength: 3},
+				},
+				RecvDeltas: []*rtcp.RecvDelta{
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 250},
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 0},
+				},
+			},
+			roundTrip: true,
+			want: []TransportFeedbackPacket{
+				{MediaSSRC: 1, FeedbackCount: 5, SequenceNumber: 10, Received: true, Delta: 1000, ArrivalTime: 129000},
+				{MediaSSRC: 1, FeedbackCount: 5, SequenceNumber: 11, Received: true, Delta: 250, ArrivalTime: 129250},
+				{MediaSSRC: 1, FeedbackCount: 5, SequenceNumber: 12, Received: true, Delta: 0, ArrivalTime: 129250},
+			},
+		},
+		{
+			name: "status vector truncated to status count",
+			cc: &rtcp.TransportLayerCC{
+				MediaSSRC:          2,
+				BaseSequenceNumber: 65534,
+				PacketStatusCount:  4,
+				ReferenceTime:      1,
+				PacketChunks: []rtcp.PacketStatusChunk{
+					&rtcp.StatusVectorChunk{
+						SymbolSize: rtcp.TypeTCCSymbolSizeTwoBit,
+						SymbolList: []uint16{
+							rtcp.TypeTCCPacketReceivedSmallDelta,
+							rtcp.TypeTCCPacketNotReceived,
+							rtcp.TypeTCCPacketReceivedLargeDelta,
+							rtcp.TypeTCCPacketReceivedSmallDelta,
+							rtcp.TypeTCCPacketNotReceived,
+							rtcp.TypeTCCPacketNotReceived,
+							rtcp.TypeTCCPacketNotReceived,
+						},
+					},
+				},
+				RecvDeltas: []*rtcp.RecvDelta{
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 500},
+					{Type: rtcp.TypeTCCPacketReceivedLargeDelta, Delta: -1000},
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 750},
+				},
+			},
+			roundTrip: true,
+			want: []TransportFeedbackPacket{
+				{MediaSSRC: 2, SequenceNumber: 65534, Received: true, Delta: 500, ArrivalTime: 64500},
+				{MediaSSRC: 2, SequenceNumber: 65535},
+				{MediaSSRC: 2, SequenceNumber: 0, Received: true, Delta: -1000, ArrivalTime: 63500},
+				{MediaSSRC: 2, SequenceNumber: 1, Received: true, Delta: 750, ArrivalTime: 64250},
+			},
+		},
+		{
+			name: "missing receive delta",
+			cc: &rtcp.TransportLayerCC{
+				MediaSSRC:          3,
+				BaseSequenceNumber: 7,
+				PacketStatusCount:  2,
+				PacketChunks: []rtcp.PacketStatusChunk{
+					&rtcp.RunLengthChunk{PacketStatusSymbol: rtcp.TypeTCCPacketReceivedSmallDelta, RunLength: 2},
+				},
+				RecvDeltas: []*rtcp.RecvDelta{
+					{Type: rtcp.TypeTCCPacketReceivedSmallDelta, Delta: 250},
+				},
+			},
+			want: []TransportFeedbackPacket{
+				{MediaSSRC: 3, SequenceNumber: 7, Received: true, Delta: 250, ArrivalTime: 250},
+				{MediaSSRC: 3, SequenceNumber: 8, Received: true, MissingDelta: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := tt.cc
+			if tt.roundTrip {
+				cc.Header = rtcp.Header{
+					Padding: cc.Len()%4 != 0,
+					Count:   rtcp.FormatTCC,
+					Type:    rtcp.TypeTransportSpecificFeedback,
+					Length:  uint16((cc.Len()+3)/4 - 1),
+				}
+				raw, err := cc.Marshal()
+				if err != nil {
+					t.Fatalf("marshal: %v", err)
+				}
+				cc = &rtcp.TransportLayerCC{}
+				if err = cc.Unmarshal(raw); err != nil {
+					t.Fatalf("unmarshal: %v", err)
+				}
+			}
+
+			got := decodeTransportFeedback(cc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeTransportFeedback() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCCFeedback(t *testing.T) {
+	report := &rtcp.CCFeedbackReport{
+		ReportTimestamp: 42,
+		ReportBlocks: []rtcp.CCFeedbackReportBlock{
+			{
+				MediaSSRC:     4,
+				BeginSequence: 100,
+				MetricBlocks: []rtcp.CCFeedbackMetricBlock{
+					{Received: true, ECN: rtcp.ECNCE, ArrivalTimeOffset: 10},
+					{Received: false},
+				},
+			},
+		},
+	}
+
+	raw, err := report.Marshal()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	decoded := &rtcp.CCFeedbackReport{}
+	if err = decoded.Unmarshal(raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := []CCFeedbackPacket{
+		{MediaSSRC: 4, SequenceNumber: 100, Received: true, ECN: uint8(rtcp.ECNCE), ArrivalTimeOffset: 10, ReportTimestamp: 42},
+		{MediaSSRC: 4, SequenceNumber: 101, ReportTimestamp: 42},
+	}
+	got := decodeCCFeedback(decoded)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeCCFeedback() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package rtcplog
+
+import (
+	"bwe/demo/pkg/attr"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+)
+
+type Record struct {
+	Timestamp         int64                     `json:"timestamp"`
+	Types             []string                  `json:"types"`
+	Size              int                       `json:"size"`
+	ReceiverReports   []ReceptionReport         `json:"receiver_reports,omitempty"`
+	TransportFeedback []TransportFeedbackPacket `json:"transport_feedback,omitempty"`
+	CCFeedback        []CCFeedbackPacket        `json:"cc_feedback,omitempty"`
+}
+
+type ReceptionReport struct {
+	ReporterSSRC       uint32 `json:"reporter_ssrc"`
+	SSRC               uint32 `json:"ssrc"`
+	FractionLost       uint8  `json:"fraction_lost"`
+	TotalLost          uint32 `json:"total_lost"`
+	LastSequenceNumber uint32 `json:"last_sequence_number"`
+	Jitter             uint32 `json:"jitter"`
+	LastSenderReport   uint32 `json:"last_sender_report"`
+	Delay              uint32 `json:"delay"`
+}
+
+type InterceptorFactory struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewInterceptor(w io.Writer) *InterceptorFactory {
+	return &InterceptorFactory{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (f *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+	return &Interceptor{factory: f}, nil
+}
+
+func (f *InterceptorFactory) write(record any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := f.encoder.Encode(record)
+	if err != nil {
+		slog.Error("encode rtcp record", attr.Error(err))
+	}
+}
+
+// Interceptor logs every outgoing RTCP batch with the time it was handed to the transport.
+// It must be the first interceptor in the registry to see packets produced by the others.
+type Interceptor struct {
+	interceptor.NoOp
+	factory *InterceptorFactory
+}
+
+func (i *Interceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	return interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, attributes interceptor.Attributes) (int, error) {
+		n, err := writer.Write(pkts, attributes)
+		if err != nil {
+			return n, err
+		}
+
+		record := Record{
+			Timestamp: time.Now().UnixNano(),
+			Types:     make([]string, 0, len(pkts)),
+			Size:      n,
+		}
+		for _, pkt := range pkts {
+			record.Types = append(record.Types, strings.TrimPrefix(fmt.Sprintf("%T", pkt), "*rtcp."))
+
+			switch pkt := pkt.(type) {
+			case *rtcp.ReceiverReport:
+				for _, report := range pkt.Reports {
+					record.ReceiverReports = append(record.ReceiverReports, ReceptionReport{
+						ReporterSSRC:       pkt.SSRC,
+						SSRC:               report.SSRC,
+						FractionLost:       report.FractionLost,
+						TotalLost:          report.TotalLost,
+						LastSequenceNumber: report.LastSequenceNumber,
+						Jitter:             report.Jitter,
+						LastSenderReport:   report.LastSenderReport,
+						Delay:              report.Delay,
+					})
+				}
+			case *rtcp.TransportLayerCC:
+				record.TransportFeedback = append(record.TransportFeedback, decodeTransportFeedback(pkt)...)
+			case *rtcp.CCFeedbackReport:
+				record.CCFeedback = append(record.CCFeedback, decodeCCFeedback(pkt)...)
+			}
+		}
+		i.factory.write(record)
+
+		return n, nil
+	})
+}
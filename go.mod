@@ -5,6 +5,9 @@ go 1.21
 require (
 	github.com/pion/interceptor v0.1.25
 	github.com/pion/logging v0.2.2
+	github.com/pion/rtcp v1.2.12
+	github.com/pion/rtp v1.8.3
+	github.com/pion/sdp/v3 v3.0.6
 	github.com/pion/webrtc/v4 v4.0.0-beta.6
 	golang.org/x/net v0.16.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -18,10 +21,7 @@ require (
 	github.com/pion/ice/v3 v3.0.1 // indirect
 	github.com/pion/mdns v0.0.8 // indirect
 	github.com/pion/randutil v0.1.0 // indirect
-	github.com/pion/rtcp v1.2.12 // indirect
-	github.com/pion/rtp v1.8.3 // indirect
 	github.com/pion/sctp v1.8.9 // indirect
-	github.com/pion/sdp/v3 v3.0.6 // indirect
 	github.com/pion/srtp/v3 v3.0.0 // indirect
 	github.com/pion/stun/v2 v2.0.0 // indirect
 	github.com/pion/transport/v2 v2.2.4 // indirect